@@ -0,0 +1,290 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: batch.go 	Parallel batch and directory uploads
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/thaikolja/gitup/internal/storage"
+)
+
+// BatchResult describes the outcome of uploading one file as part of a batch.
+type BatchResult struct {
+	// SourcePath is the original, on-disk path the file was read from.
+	SourcePath string
+	// UploadPath is the folder-routed, unique destination path.
+	UploadPath string
+	// URL is the public URL of the uploaded file, empty on error or dry-run.
+	URL string
+	// Err is set if this file failed to upload.
+	Err error
+}
+
+// BatchUploader uploads multiple files or whole directories, in parallel,
+// preferring a single atomic commit when the storage backend supports one.
+type BatchUploader struct {
+	Storage         storage.Storage // Destination the files are uploaded to
+	Config          Config          // Folder routing and output template settings
+	Concurrency     int             // Bounded worker pool size for non-batch drivers
+	MessageTemplate string          // Commit message; supports {count} and {branch}
+	Branch          string          // Git branch for uploaded files
+	DryRun          bool            // Preview routing without uploading anything
+
+	// claimed tracks destination paths already handed out to a file within
+	// this batch, so two source files that sanitize to the same name (e.g.
+	// a/icon.png and b/icon.png) don't both resolve to the same uploadPath.
+	// Checking storage.Exists alone only catches collisions with what's
+	// already on the remote, not with siblings in the same batch.
+	claimed sync.Map
+}
+
+// Upload resolves paths (files and/or directories, recursively) to a flat
+// file list and uploads them. Results are returned in the same order the
+// files were discovered in.
+func (b *BatchUploader) Upload(ctx context.Context, paths []string) ([]BatchResult, error) {
+	files, err := collectFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found in %v", paths)
+	}
+
+	message := renderBatchMessage(b.MessageTemplate, len(files), b.Branch)
+
+	if b.DryRun {
+		return b.previewRouting(files), nil
+	}
+
+	if batcher, ok := b.Storage.(storage.BatchStorage); ok {
+		results, err := b.uploadSingleCommit(ctx, batcher, files, message)
+		if err != storage.ErrBatchUnsupported {
+			return results, err
+		}
+	}
+
+	return b.uploadParallel(ctx, files, message)
+}
+
+// previewRouting computes each file's destination path without touching the
+// network, for --dry-run. It still dedupes sanitized names against earlier
+// files in the same batch, so the preview matches what a real run would do.
+func (b *BatchUploader) previewRouting(files []string) []BatchResult {
+	claimed := map[string]bool{}
+	results := make([]BatchResult, len(files))
+	for i, sourcePath := range files {
+		sanitized := sanitizeFilename(filepath.Base(sourcePath))
+		folder := getUploadFolder(sanitized, b.Config)
+		results[i] = BatchResult{SourcePath: sourcePath, UploadPath: uniqueLocalPath(claimed, folder, sanitized)}
+	}
+	return results
+}
+
+// uploadSingleCommit resolves unique destination paths for every file, then
+// commits them all at once through batcher.
+func (b *BatchUploader) uploadSingleCommit(ctx context.Context, batcher storage.BatchStorage, files []string, message string) ([]BatchResult, error) {
+	results := make([]BatchResult, len(files))
+	batchFiles := make([]storage.BatchFile, 0, len(files))
+
+	for i, sourcePath := range files {
+		data, uploadPath, err := b.prepareFile(ctx, sourcePath)
+		results[i] = BatchResult{SourcePath: sourcePath, UploadPath: uploadPath, Err: err}
+		if err != nil {
+			continue
+		}
+		batchFiles = append(batchFiles, storage.BatchFile{Path: uploadPath, Data: data})
+	}
+
+	urls, err := batcher.PutBatch(ctx, batchFiles, storage.Metadata{CommitMessage: message})
+	if err == storage.ErrBatchUnsupported {
+		// Release the paths claimed above so the per-file fallback can claim
+		// them again instead of skipping to a -1 suffix.
+		for _, file := range batchFiles {
+			b.claimed.Delete(file.Path)
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		results[i].URL = urls[results[i].UploadPath]
+	}
+	return results, nil
+}
+
+// uploadParallel uploads each file independently through a bounded worker
+// pool, for drivers that have no atomic multi-file commit path.
+func (b *BatchUploader) uploadParallel(ctx context.Context, files []string, message string) ([]BatchResult, error) {
+	concurrency := b.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]BatchResult, len(files))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = b.uploadOne(ctx, files[i], message)
+			}
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+func (b *BatchUploader) uploadOne(ctx context.Context, sourcePath, message string) BatchResult {
+	data, uploadPath, err := b.prepareFile(ctx, sourcePath)
+	if err != nil {
+		return BatchResult{SourcePath: sourcePath, UploadPath: uploadPath, Err: err}
+	}
+
+	url, err := b.Storage.Put(ctx, uploadPath, data, storage.Metadata{
+		Filename:      filepath.Base(uploadPath),
+		CommitMessage: message,
+	})
+	return BatchResult{SourcePath: sourcePath, UploadPath: uploadPath, URL: url, Err: err}
+}
+
+// prepareFile reads, sanitizes, routes, and de-duplicates the destination
+// path for a single file, without uploading it.
+func (b *BatchUploader) prepareFile(ctx context.Context, sourcePath string) (data []byte, uploadPath string, err error) {
+	data, err = os.ReadFile(sourcePath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", sourcePath, err)
+	}
+
+	sanitized := sanitizeFilename(filepath.Base(sourcePath))
+	folder := getUploadFolder(sanitized, b.Config)
+
+	uploadPath, err = b.claimUploadPath(ctx, folder, sanitized)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to determine unique filename for %s: %w", sourcePath, err)
+	}
+
+	return data, uploadPath, nil
+}
+
+// claimUploadPath finds a destination path under folder for filename that is
+// free both on the remote (storage.Exists) and within this batch, appending
+// -1, -2, etc. before the extension as needed. Candidates are claimed via
+// LoadOrStore before the remote check so concurrent callers (uploadParallel's
+// worker pool) can't race each other onto the same path.
+func (b *BatchUploader) claimUploadPath(ctx context.Context, folder, filename string) (string, error) {
+	base := strings.TrimSuffix(filename, filepath.Ext(filename))
+	ext := filepath.Ext(filename)
+
+	candidate := filename
+	counter := 1
+	for {
+		uploadPath := filepath.Join(folder, candidate)
+
+		if _, alreadyClaimed := b.claimed.LoadOrStore(uploadPath, struct{}{}); !alreadyClaimed {
+			exists, err := b.Storage.Exists(ctx, uploadPath)
+			if err != nil {
+				b.claimed.Delete(uploadPath)
+				return "", err
+			}
+			if !exists {
+				return uploadPath, nil
+			}
+			// Taken on the remote; leave it claimed (it's genuinely in use)
+			// and fall through to try the next suffix.
+		}
+
+		candidate = fmt.Sprintf("%s-%d%s", base, counter, ext)
+		counter++
+	}
+}
+
+// uniqueLocalPath is claimUploadPath's network-free counterpart, used by
+// --dry-run's preview so repeated sanitized names are deduped the same way a
+// real run would, without touching storage.Exists.
+func uniqueLocalPath(claimed map[string]bool, folder, filename string) string {
+	base := strings.TrimSuffix(filename, filepath.Ext(filename))
+	ext := filepath.Ext(filename)
+
+	candidate := filename
+	counter := 1
+	for {
+		uploadPath := filepath.Join(folder, candidate)
+		if !claimed[uploadPath] {
+			claimed[uploadPath] = true
+			return uploadPath
+		}
+		candidate = fmt.Sprintf("%s-%d%s", base, counter, ext)
+		counter++
+	}
+}
+
+// collectFiles expands paths (files or directories) into a flat, sorted
+// list of file paths, walking directories recursively.
+func collectFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not access %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(walkPath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				files = append(files, walkPath)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk %s: %w", path, err)
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// renderBatchMessage expands {count} and {branch} placeholders in template.
+// An empty template falls back to "Upload <count> files via GitUp".
+func renderBatchMessage(template string, count int, branch string) string {
+	if template == "" {
+		return fmt.Sprintf("Upload %d files via GitUp", count)
+	}
+	message := strings.ReplaceAll(template, "{count}", fmt.Sprint(count))
+	message = strings.ReplaceAll(message, "{branch}", branch)
+	return message
+}
@@ -0,0 +1,76 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: batch_test.go 	Tests for file collection, batch messages, and path dedup
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCollectFilesExpandsDirectoriesRecursively(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a.txt", filepath.Join("sub", "b.txt")} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := collectFiles([]string{root})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("got %d files, want 2: %v", len(files), files)
+	}
+}
+
+func TestCollectFilesMissingPath(t *testing.T) {
+	if _, err := collectFiles([]string{filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("expected an error for a nonexistent path")
+	}
+}
+
+func TestRenderBatchMessageDefault(t *testing.T) {
+	got := renderBatchMessage("", 3, "main")
+	if got != "Upload 3 files via GitUp" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestRenderBatchMessagePlaceholders(t *testing.T) {
+	got := renderBatchMessage("Add {count} files to {branch}", 2, "release")
+	if got != "Add 2 files to release" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestUniqueLocalPathDedupesSiblings(t *testing.T) {
+	claimed := map[string]bool{}
+
+	first := uniqueLocalPath(claimed, "img", "icon.png")
+	second := uniqueLocalPath(claimed, "img", "icon.png")
+
+	if first == second {
+		t.Fatalf("expected distinct paths for two sanitized-to-the-same-name siblings, got %q twice", first)
+	}
+	if first != filepath.Join("img", "icon.png") {
+		t.Errorf("first = %q, want %q", first, filepath.Join("img", "icon.png"))
+	}
+	if second != filepath.Join("img", "icon-1.png") {
+		t.Errorf("second = %q, want %q", second, filepath.Join("img", "icon-1.png"))
+	}
+}
@@ -0,0 +1,357 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: config.go 	Configuration loading, folder routing, and output templates
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/thaikolja/gitup/internal/secret"
+)
+
+// configDir is the directory under the user's home where the configuration is stored.
+const configDir = ".gitup"
+
+// configFileJSON and configFileTOML are the two config formats GitUp accepts.
+// loadConfig checks config.toml first and config.json second, so existing
+// JSON installs (which have no config.toml) keep working unmodified.
+const (
+	configFileJSON = "config.json"
+	configFileTOML = "config.toml"
+)
+
+// Config holds the user's GitUp configuration.
+type Config struct {
+	// Token is the access token used to authenticate API requests.
+	Token string `json:"token" toml:"token"`
+	// Repository is the target repository in the format owner/repo (github driver).
+	Repository string `json:"repository" toml:"repository"`
+	// Provider selects the Git hosting service the github driver talks to:
+	// github (default), gitlab, gitea/forgejo, or bitbucket.
+	Provider string `json:"provider" toml:"provider"`
+	// BaseURL overrides the provider's default API host, for self-hosted
+	// GitLab/Gitea/Forgejo/Bitbucket instances.
+	BaseURL string `json:"base_url" toml:"base_url"`
+	// Driver selects the storage backend: github, s3, gcs, local, or git.
+	Driver string `json:"driver" toml:"driver"`
+	// Source is the bucket name, container name, or local directory the driver writes to.
+	Source string `json:"source" toml:"source"`
+	// Region is the cloud region to use, when the driver requires one (s3).
+	Region string `json:"region" toml:"region"`
+	// Endpoint overrides the default API endpoint, for S3-compatible stores.
+	Endpoint string `json:"endpoint" toml:"endpoint"`
+	// SSHRemote is the "user@host:path" remote the git driver pushes to.
+	SSHRemote string `json:"ssh_remote" toml:"ssh_remote"`
+
+	// Folders maps file extensions (".png") or glob patterns ("*.screenshot.*")
+	// to destination folders, overriding the built-in defaults.
+	Folders map[string]string `json:"folders" toml:"folders"`
+	// DefaultFolder is used for files that match neither Folders nor the
+	// built-in defaults. Falls back to "files" if empty.
+	DefaultFolder string `json:"default_folder" toml:"default_folder"`
+	// CommitMessageTemplate replaces the hardcoded "Upload %s via GitUp"
+	// message. Supports {filename}, {ext}, {date}, and {user} placeholders.
+	CommitMessageTemplate string `json:"commit_message_template" toml:"commit_message_template"`
+	// OutputTemplates maps a file extension to a text/template string
+	// rendered with {{.Filename}}, {{.URL}}, and {{.Ext}}, so users can emit
+	// HTML, BBCode, Org-mode, or reST instead of only markdown.
+	OutputTemplates map[string]string `json:"output_templates" toml:"output_templates"`
+}
+
+// builtinFolderMap is the default extension->folder routing, used for any
+// extension not overridden by Config.Folders.
+var builtinFolderMap = map[string]string{
+	// Images
+	".png":  "img",
+	".jpg":  "img",
+	".jpeg": "img",
+	".gif":  "img",
+	".svg":  "img",
+	".webp": "img",
+	".ico":  "img",
+
+	// Data files
+	".json": "data",
+	".xml":  "data",
+	".csv":  "data",
+	".yaml": "data",
+	".yml":  "data",
+	".toml": "data",
+
+	// Documents
+	".pdf":  "docs",
+	".md":   "docs",
+	".txt":  "docs",
+	".doc":  "docs",
+	".docx": "docs",
+
+	// Videos
+	".mp4":  "video",
+	".mov":  "video",
+	".avi":  "video",
+	".webm": "video",
+
+	// Audio
+	".mp3":  "audio",
+	".wav":  "audio",
+	".ogg":  "audio",
+	".flac": "audio",
+
+	// Archives
+	".zip": "archives",
+	".tar": "archives",
+	".gz":  "archives",
+	".rar": "archives",
+}
+
+// builtinImageExts lists the extensions the default output template renders
+// as a markdown image rather than a link.
+var builtinImageExts = []string{".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp"}
+
+// getUploadFolder returns the destination folder for filename, checking
+// config.Folders (exact extension or glob match) before the built-in
+// defaults, and finally config.DefaultFolder or "files".
+func getUploadFolder(filename string, config Config) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+
+	for pattern, folder := range config.Folders {
+		if pattern == ext {
+			return folder
+		}
+		if matched, err := filepath.Match(pattern, filename); err == nil && matched {
+			return folder
+		}
+	}
+
+	if folder, exists := builtinFolderMap[ext]; exists {
+		return folder
+	}
+
+	if config.DefaultFolder != "" {
+		return config.DefaultFolder
+	}
+	return "files"
+}
+
+// outputData is the value text/template renders an output_template against.
+type outputData struct {
+	Filename string
+	URL      string
+	Ext      string
+}
+
+// formatOutput renders the markdown (or user-configured) snippet GitUp
+// prints after a successful upload.
+func formatOutput(filename, url string, config Config) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	data := outputData{Filename: filename, URL: url, Ext: ext}
+
+	if tmplString, ok := config.OutputTemplates[ext]; ok {
+		rendered, err := renderTemplate(tmplString, data)
+		if err == nil {
+			return rendered
+		}
+		fmt.Fprintf(os.Stderr, "Warning: invalid output_template for %s: %v\n", ext, err)
+	}
+
+	for _, imgExt := range builtinImageExts {
+		if ext == imgExt {
+			return fmt.Sprintf("![%s](%s)", filename, url)
+		}
+	}
+	return fmt.Sprintf("[%s](%s)", filename, url)
+}
+
+func renderTemplate(tmplString string, data outputData) (string, error) {
+	tmpl, err := template.New("output").Parse(tmplString)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderCommitMessage expands {filename}, {ext}, {date}, and {user}
+// placeholders in config.CommitMessageTemplate. An empty template falls
+// back to the original "Upload %s via GitUp" message.
+func renderCommitMessage(config Config, filename string) string {
+	tmpl := config.CommitMessageTemplate
+	if tmpl == "" {
+		return fmt.Sprintf("Upload %s via GitUp", filename)
+	}
+
+	message := strings.ReplaceAll(tmpl, "{filename}", filename)
+	message = strings.ReplaceAll(message, "{ext}", strings.ToLower(filepath.Ext(filename)))
+	message = strings.ReplaceAll(message, "{date}", time.Now().Format("2006-01-02"))
+	message = strings.ReplaceAll(message, "{user}", secretUser())
+	return message
+}
+
+// secretUser identifies the current user within the secret store. USER is
+// unset on native Windows (which uses USERNAME instead), so os/user.Current
+// is tried first; both env vars remain as fallbacks for restricted
+// environments where it fails (e.g. no CGO, no /etc/passwd entry).
+func secretUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if username := os.Getenv("USERNAME"); username != "" {
+		return username
+	}
+	return os.Getenv("USER")
+}
+
+// configureGitUp runs an interactive configuration flow.
+// It prompts the user for a provider (and, for gitea/forgejo, a base URL), an
+// access token, and a repository, saves the token via
+// the secret package, and writes the remainder of the config to disk.
+func configureGitUp() {
+	fmt.Println("=== GitUp Configuration ===")
+
+	var config Config
+
+	// Get provider
+	fmt.Print("Select provider [github, gitlab, gitea, bitbucket]: ")
+	if _, err := fmt.Scanln(&config.Provider); err != nil {
+		fmt.Printf("Error reading provider: %v\n", err)
+		os.Exit(1)
+	}
+
+	if config.Provider == "gitea" || config.Provider == "forgejo" {
+		fmt.Print("Enter instance base URL (e.g. https://gitea.example.com): ")
+		if _, err := fmt.Scanln(&config.BaseURL); err != nil {
+			fmt.Printf("Error reading base URL: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Get access token
+	fmt.Print("Enter your Personal Access Token: ")
+	if _, err := fmt.Scanln(&config.Token); err != nil {
+		fmt.Printf("Error reading token: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Get repository
+	fmt.Print("Enter repository (owner/repo): ")
+	if _, err := fmt.Scanln(&config.Repository); err != nil {
+		fmt.Printf("Error reading repository: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Save the token via the OS keyring (or its encrypted-file fallback)
+	backend, err := secret.Save(secretUser(), config.Token)
+	if err != nil {
+		fmt.Printf("Warning: Could not save token securely: %v\n", err)
+		fmt.Println("Token will be saved in config file instead")
+	} else {
+		fmt.Printf("✓ Token saved to %s\n", backend)
+		config.Token = "" // Don't store in file if it's in the keyring/encrypted file
+	}
+
+	// Save config
+	err = saveConfig(config)
+	if err != nil {
+		fmt.Printf("Error saving config: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("✓ Configuration saved!")
+}
+
+// rotateStoredToken replaces the stored token in place, leaving the rest of
+// the config (repository, driver, folders, etc.) untouched.
+func rotateStoredToken() {
+	fmt.Print("Enter your new GitHub Personal Access Token: ")
+	var token string
+	if _, err := fmt.Scanln(&token); err != nil {
+		fmt.Printf("Error reading token: %v\n", err)
+		os.Exit(1)
+	}
+
+	backend, err := secret.Rotate(secretUser(), token)
+	if err != nil {
+		fmt.Printf("Error rotating token: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Token rotated in %s\n", backend)
+}
+
+// saveConfig writes the provided Config to the user's config directory as
+// JSON. The config directory is created with restrictive permissions.
+func saveConfig(config Config) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	configPath := filepath.Join(homeDir, configDir)
+
+	if err := os.MkdirAll(configPath, 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	configFilePath := filepath.Join(configPath, configFileJSON)
+	return os.WriteFile(configFilePath, data, 0600)
+}
+
+// loadConfig reads GitUp's configuration file, preferring config.toml over
+// config.json if both are present, and attempts to load the token from the
+// OS keyring/encrypted file if it isn't present in the config file.
+func loadConfig() (Config, error) {
+	var config Config
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return config, fmt.Errorf("failed to determine home directory: %w", err)
+	}
+
+	tomlPath := filepath.Join(homeDir, configDir, configFileTOML)
+	if data, err := os.ReadFile(tomlPath); err == nil {
+		if err := toml.Unmarshal(data, &config); err != nil {
+			return config, err
+		}
+	} else {
+		jsonPath := filepath.Join(homeDir, configDir, configFileJSON)
+		data, err := os.ReadFile(jsonPath)
+		if err != nil {
+			return config, err
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return config, err
+		}
+	}
+
+	// Try to load the token from the OS keyring/encrypted file if not in config
+	if config.Token == "" {
+		token, err := secret.Load(secretUser())
+		if err == nil {
+			config.Token = token
+		}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: config_test.go 	Tests for folder routing, commit messages, and output templates
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetUploadFolder(t *testing.T) {
+	config := Config{
+		Folders: map[string]string{
+			".png":              "screenshots",
+			"*.screenshot.*":    "screenshots",
+			".unusedbuiltinext": "should-not-match",
+		},
+		DefaultFolder: "misc",
+	}
+
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{"photo.png", "screenshots"},          // config override beats the builtin ("img")
+		{"bug.screenshot.jpg", "screenshots"}, // glob pattern match
+		{"report.pdf", "docs"},                // builtin default, no override
+		{"archive.unknownext", "misc"},        // no match anywhere, falls back to DefaultFolder
+	}
+
+	for _, tt := range tests {
+		if got := getUploadFolder(tt.filename, config); got != tt.want {
+			t.Errorf("getUploadFolder(%q) = %q, want %q", tt.filename, got, tt.want)
+		}
+	}
+}
+
+func TestGetUploadFolderFallsBackToFiles(t *testing.T) {
+	config := Config{}
+	if got := getUploadFolder("archive.unknownext", config); got != "files" {
+		t.Errorf("got %q, want %q", got, "files")
+	}
+}
+
+func TestRenderCommitMessageDefault(t *testing.T) {
+	got := renderCommitMessage(Config{}, "photo.png")
+	if got != "Upload photo.png via GitUp" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestRenderCommitMessagePlaceholders(t *testing.T) {
+	config := Config{CommitMessageTemplate: "Add {filename} ({ext})"}
+	got := renderCommitMessage(config, "photo.PNG")
+	if got != "Add photo.PNG (.png)" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestFormatOutputImage(t *testing.T) {
+	got := formatOutput("photo.png", "https://example.com/photo.png", Config{})
+	want := "![photo.png](https://example.com/photo.png)"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormatOutputNonImage(t *testing.T) {
+	got := formatOutput("notes.txt", "https://example.com/notes.txt", Config{})
+	want := "[notes.txt](https://example.com/notes.txt)"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormatOutputCustomTemplate(t *testing.T) {
+	config := Config{OutputTemplates: map[string]string{".txt": "{{.Filename}} -> {{.URL}}"}}
+	got := formatOutput("notes.txt", "https://example.com/notes.txt", config)
+	if !strings.Contains(got, "notes.txt -> https://example.com/notes.txt") {
+		t.Errorf("got %q", got)
+	}
+}
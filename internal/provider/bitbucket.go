@@ -0,0 +1,118 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/provider/bitbucket.go 	Bitbucket Cloud "src" API client
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+const defaultBitbucketBaseURL = "https://api.bitbucket.org/2.0"
+
+// bitbucketClient talks to Bitbucket Cloud's "src" endpoint
+// (/repositories/{workspace}/{repo}/src), which takes the file content as a
+// multipart form field named after its path.
+type bitbucketClient struct {
+	client    *http.Client
+	baseURL   string
+	workspace string
+	repo      string
+	branch    string
+	token     string
+}
+
+func newBitbucketClient(opts Options) RepoClient {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBitbucketBaseURL
+	}
+	return &bitbucketClient{
+		client:    newHTTPClient(),
+		baseURL:   baseURL,
+		workspace: opts.Owner,
+		repo:      opts.Repo,
+		branch:    opts.Branch,
+		token:     opts.Token,
+	}
+}
+
+func (c *bitbucketClient) Exists(ctx context.Context, path string) (bool, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/src/%s/%s", c.baseURL, c.workspace, c.repo, c.branch, path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("unexpected Bitbucket API response while checking path (%s): %s - %s", path, resp.Status, string(body))
+	}
+}
+
+func (c *bitbucketClient) PutFile(ctx context.Context, path string, data []byte, message string) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile(path, path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := part.Write(data); err != nil {
+		return "", err
+	}
+	if err := writer.WriteField("branch", c.branch); err != nil {
+		return "", err
+	}
+	if err := writer.WriteField("message", message); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/repositories/%s/%s/src", c.baseURL, c.workspace, c.repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("Bitbucket API error: %s - %s", resp.Status, string(respBody))
+	}
+
+	return fmt.Sprintf("https://bitbucket.org/%s/%s/raw/%s/%s", c.workspace, c.repo, c.branch, path), nil
+}
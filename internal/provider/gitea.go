@@ -0,0 +1,104 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/provider/gitea.go 	Gitea/Forgejo Contents API client
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// giteaClient talks to the Gitea/Forgejo Contents API
+// (/repos/{owner}/{repo}/contents/{filepath}), which both projects implement
+// identically.
+type giteaClient struct {
+	client  *http.Client
+	baseURL string
+	owner   string
+	repo    string
+	branch  string
+	token   string
+}
+
+func newGiteaClient(opts Options) RepoClient {
+	return &giteaClient{
+		client:  newHTTPClient(),
+		baseURL: opts.BaseURL,
+		owner:   opts.Owner,
+		repo:    opts.Repo,
+		branch:  opts.Branch,
+		token:   opts.Token,
+	}
+}
+
+func (c *giteaClient) contentsURL(path string) string {
+	return fmt.Sprintf("%s/api/v1/repos/%s/%s/contents/%s", c.baseURL, c.owner, c.repo, path)
+}
+
+func (c *giteaClient) Exists(ctx context.Context, path string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.contentsURL(path)+"?ref="+c.branch, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "token "+c.token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("unexpected Gitea API response while checking path (%s): %s - %s", path, resp.Status, string(body))
+	}
+}
+
+func (c *giteaClient) PutFile(ctx context.Context, path string, data []byte, message string) (string, error) {
+	requestBody := map[string]string{
+		"branch":  c.branch,
+		"content": base64.StdEncoding.EncodeToString(data),
+		"message": message,
+	}
+	bodyJSON, err := json.Marshal(requestBody)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.contentsURL(path), bytes.NewReader(bodyJSON))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "token "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("Gitea API error: %s - %s", resp.Status, string(body))
+	}
+
+	return fmt.Sprintf("%s/%s/%s/raw/branch/%s/%s", c.baseURL, c.owner, c.repo, c.branch, path), nil
+}
@@ -0,0 +1,105 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/provider/github.go 	GitHub Contents API client
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// githubClient talks to GitHub's Contents API. This is GitUp's original
+// upload path, and remains the default provider.
+type githubClient struct {
+	client *http.Client
+	owner  string
+	repo   string
+	branch string
+	token  string
+}
+
+func newGitHubClient(opts Options) RepoClient {
+	return &githubClient{
+		client: newHTTPClient(),
+		owner:  opts.Owner,
+		repo:   opts.Repo,
+		branch: opts.Branch,
+		token:  opts.Token,
+	}
+}
+
+func (c *githubClient) Exists(ctx context.Context, path string) (bool, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", c.owner, c.repo, path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false, fmt.Errorf("GitHub API auth error while checking path (%s): %s", path, resp.Status)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("unexpected GitHub API response while checking path (%s): %s - %s", path, resp.Status, string(body))
+	}
+}
+
+func (c *githubClient) PutFile(ctx context.Context, path string, data []byte, message string) (string, error) {
+	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", c.owner, c.repo, path)
+
+	requestBody := map[string]string{
+		"message": message,
+		"content": base64.StdEncoding.EncodeToString(data),
+		"branch":  c.branch,
+	}
+	bodyJSON, err := json.Marshal(requestBody)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, apiURL, bytes.NewReader(bodyJSON))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "token "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("GitHub API error: %s - %s", resp.Status, string(body))
+	}
+
+	return c.rawURL(path), nil
+}
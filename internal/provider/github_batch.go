@@ -0,0 +1,101 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/provider/github_batch.go 	Single-commit multi-file uploads via the Git Data API
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// batchBlobConcurrency bounds how many blob-creation requests run at once
+// during a batch upload, independent of the caller's own worker pool.
+const batchBlobConcurrency = 8
+
+// BatchFile pairs a destination path with its file contents for a
+// multi-file commit.
+type BatchFile struct {
+	Path string
+	Data []byte
+}
+
+// BatchRepoClient is implemented by providers that can commit several files
+// in a single atomic operation rather than one commit per file. Only the
+// github provider supports this today, via the Git Data API.
+type BatchRepoClient interface {
+	PutBatch(ctx context.Context, files []BatchFile, message string) (map[string]string, error)
+}
+
+// PutBatch uploads files as blobs in parallel, then assembles them into a
+// single tree, commit, and ref update, so N files become one commit instead
+// of N Contents API calls.
+func (c *githubClient) PutBatch(ctx context.Context, files []BatchFile, message string) (map[string]string, error) {
+	if len(files) == 0 {
+		return map[string]string{}, nil
+	}
+
+	shas := make([]string, len(files))
+	errs := make([]error, len(files))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchBlobConcurrency)
+	for i, file := range files {
+		wg.Add(1)
+		go func(i int, file BatchFile) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			shas[i], errs[i] = c.createBlob(ctx, file.Data, nil)
+		}(i, file)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to create blob for %s: %w", files[i].Path, err)
+		}
+	}
+
+	baseSHA, err := c.headCommitSHA(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read branch head: %w", err)
+	}
+
+	baseTreeSHA, err := c.commitTreeSHA(ctx, baseSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read base tree: %w", err)
+	}
+
+	entries := make([]treeEntry, len(files))
+	for i, file := range files {
+		entries[i] = treeEntry{path: file.Path, sha: shas[i]}
+	}
+
+	treeSHA, err := c.createTree(ctx, baseTreeSHA, entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tree: %w", err)
+	}
+
+	commitSHA, err := c.createCommit(ctx, message, treeSHA, baseSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	if err := c.updateRef(ctx, commitSHA); err != nil {
+		return nil, fmt.Errorf("failed to update branch ref: %w", err)
+	}
+
+	urls := make(map[string]string, len(files))
+	for _, file := range files {
+		urls[file.Path] = c.rawURL(file.Path)
+	}
+	return urls, nil
+}
@@ -0,0 +1,284 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/provider/github_large.go 	GitHub Git Data API path for files over the Contents API's limit
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// MaxLargeFileBytes is the hard cap GitUp enforces for the Git Data API
+// path, matching GitHub's own blob size limit.
+const MaxLargeFileBytes = 100 * 1024 * 1024 // 100 MB, GitHub's blob limit
+
+// ProgressFunc is called periodically during a large-file upload with the
+// number of bytes written so far and the total size.
+type ProgressFunc func(written, total int64)
+
+// LargeFileUploader is implemented by providers that offer an upload path
+// for files too large for their standard contents/file API. The github
+// provider is the only one that implements it today.
+type LargeFileUploader interface {
+	PutLargeFile(ctx context.Context, path string, data []byte, message string, onProgress ProgressFunc) (rawURL string, err error)
+}
+
+// PutLargeFile uploads data via the Git Data API: create a blob, read the
+// branch's current commit and its tree, build a new tree and commit on top
+// of it, then fast-forward the branch ref. This bypasses the Contents API's
+// base64 request-body limit, at the cost of five extra round trips.
+func (c *githubClient) PutLargeFile(ctx context.Context, path string, data []byte, message string, onProgress ProgressFunc) (string, error) {
+	if len(data) > MaxLargeFileBytes {
+		return "", fmt.Errorf("file is %d bytes, which exceeds GitHub's %d byte blob limit", len(data), MaxLargeFileBytes)
+	}
+
+	blobSHA, err := c.createBlob(ctx, data, onProgress)
+	if err != nil {
+		return "", fmt.Errorf("failed to create blob: %w", err)
+	}
+
+	refSHA, err := c.headCommitSHA(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read branch head: %w", err)
+	}
+
+	baseTreeSHA, err := c.commitTreeSHA(ctx, refSHA)
+	if err != nil {
+		return "", fmt.Errorf("failed to read base tree: %w", err)
+	}
+
+	treeSHA, err := c.createTree(ctx, baseTreeSHA, []treeEntry{{path: path, sha: blobSHA}})
+	if err != nil {
+		return "", fmt.Errorf("failed to create tree: %w", err)
+	}
+
+	commitSHA, err := c.createCommit(ctx, message, treeSHA, refSHA)
+	if err != nil {
+		return "", fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	if err := c.updateRef(ctx, commitSHA); err != nil {
+		return "", fmt.Errorf("failed to update branch ref: %w", err)
+	}
+
+	return c.rawURL(path), nil
+}
+
+// rawURL builds the raw.githubusercontent.com URL a committed file is
+// reachable at.
+func (c *githubClient) rawURL(path string) string {
+	return fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", c.owner, c.repo, c.branch, path)
+}
+
+// progressReader wraps a reader, reporting cumulative bytes read to
+// onProgress as the HTTP client consumes the request body.
+type progressReader struct {
+	r          io.Reader
+	total      int64
+	written    int64
+	onProgress ProgressFunc
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	if n > 0 {
+		p.written += int64(n)
+		if p.onProgress != nil {
+			p.onProgress(p.written, p.total)
+		}
+	}
+	return n, err
+}
+
+func (c *githubClient) doJSON(ctx context.Context, method, url string, requestBody, responseBody any) error {
+	var bodyReader io.Reader
+	if requestBody != nil {
+		bodyJSON, err := json.Marshal(requestBody)
+		if err != nil {
+			return err
+		}
+		bodyReader = bytes.NewReader(bodyJSON)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GitHub API error: %s - %s", resp.Status, string(body))
+	}
+
+	if responseBody != nil {
+		return json.NewDecoder(resp.Body).Decode(responseBody)
+	}
+	return nil
+}
+
+func (c *githubClient) createBlob(ctx context.Context, data []byte, onProgress ProgressFunc) (string, error) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	bodyJSON, err := json.Marshal(map[string]string{
+		"content":  encoded,
+		"encoding": "base64",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/blobs", c.owner, c.repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &progressReader{
+		r:          bytes.NewReader(bodyJSON),
+		total:      int64(len(bodyJSON)),
+		onProgress: onProgress,
+	})
+	if err != nil {
+		return "", err
+	}
+	// GitHub doesn't accept chunked transfer encoding for this endpoint, so
+	// the Content-Length must be set explicitly rather than left to Go's
+	// default chunked behavior for unknown-length bodies.
+	req.ContentLength = int64(len(bodyJSON))
+	req.Header.Set("Authorization", "token "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	// The base64-encoded blob can be up to ~133 MB (MaxLargeFileBytes), so
+	// this request needs a timeout scaled to its size rather than c.client's
+	// httpTimeout, which is sized for small Contents-API calls.
+	client := &http.Client{Timeout: largeUploadTimeout(int64(len(bodyJSON)))}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("GitHub API error: %s - %s", resp.Status, string(body))
+	}
+
+	var result struct {
+		SHA string `json:"sha"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return result.SHA, nil
+}
+
+func (c *githubClient) headCommitSHA(ctx context.Context) (string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/ref/heads/%s", c.owner, c.repo, c.branch)
+
+	var result struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, url, nil, &result); err != nil {
+		return "", err
+	}
+	return result.Object.SHA, nil
+}
+
+// commitTreeSHA fetches the commit object for commitSHA and returns the SHA
+// of the tree it points at. The Git Data API's "create a tree" call requires
+// a tree SHA for base_tree, not a commit SHA, so this sits between
+// headCommitSHA and createTree in the upload path.
+func (c *githubClient) commitTreeSHA(ctx context.Context, commitSHA string) (string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/commits/%s", c.owner, c.repo, commitSHA)
+
+	var result struct {
+		Tree struct {
+			SHA string `json:"sha"`
+		} `json:"tree"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, url, nil, &result); err != nil {
+		return "", err
+	}
+	return result.Tree.SHA, nil
+}
+
+// treeEntry is one file placed into a Git tree: a path paired with the SHA
+// of the blob already uploaded for it.
+type treeEntry struct {
+	path string
+	sha  string
+}
+
+// createTree builds a new tree on top of baseTreeSHA (the base commit's
+// *tree* SHA, not its commit SHA) containing entries.
+func (c *githubClient) createTree(ctx context.Context, baseTreeSHA string, entries []treeEntry) (string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/trees", c.owner, c.repo)
+
+	tree := make([]map[string]string, len(entries))
+	for i, entry := range entries {
+		tree[i] = map[string]string{
+			"path": entry.path,
+			"mode": "100644",
+			"type": "blob",
+			"sha":  entry.sha,
+		}
+	}
+
+	requestBody := map[string]any{
+		"base_tree": baseTreeSHA,
+		"tree":      tree,
+	}
+
+	var result struct {
+		SHA string `json:"sha"`
+	}
+	if err := c.doJSON(ctx, http.MethodPost, url, requestBody, &result); err != nil {
+		return "", err
+	}
+	return result.SHA, nil
+}
+
+func (c *githubClient) createCommit(ctx context.Context, message, treeSHA, parentSHA string) (string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/commits", c.owner, c.repo)
+
+	requestBody := map[string]any{
+		"message": message,
+		"tree":    treeSHA,
+		"parents": []string{parentSHA},
+	}
+
+	var result struct {
+		SHA string `json:"sha"`
+	}
+	if err := c.doJSON(ctx, http.MethodPost, url, requestBody, &result); err != nil {
+		return "", err
+	}
+	return result.SHA, nil
+}
+
+func (c *githubClient) updateRef(ctx context.Context, commitSHA string) error {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/refs/heads/%s", c.owner, c.repo, c.branch)
+
+	requestBody := map[string]any{
+		"sha":   commitSHA,
+		"force": false,
+	}
+	return c.doJSON(ctx, http.MethodPatch, url, requestBody, nil)
+}
@@ -0,0 +1,120 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/provider/gitlab.go 	GitLab Repository Files API client
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const defaultGitLabBaseURL = "https://gitlab.com"
+
+// gitlabClient talks to GitLab's "Repository files" API
+// (/projects/:id/repository/files/:file_path), authenticating with a
+// personal or project access token.
+type gitlabClient struct {
+	client  *http.Client
+	baseURL string
+	project string // URL-encoded "owner/repo", GitLab's :id
+	branch  string
+	token   string
+}
+
+func newGitLabClient(opts Options) RepoClient {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = defaultGitLabBaseURL
+	}
+	return &gitlabClient{
+		client:  newHTTPClient(),
+		baseURL: baseURL,
+		project: url.QueryEscape(opts.Owner + "/" + opts.Repo),
+		branch:  opts.Branch,
+		token:   opts.Token,
+	}
+}
+
+func (c *gitlabClient) fileURL(path string) string {
+	return fmt.Sprintf("%s/api/v4/projects/%s/repository/files/%s", c.baseURL, c.project, url.PathEscape(path))
+}
+
+func (c *gitlabClient) Exists(ctx context.Context, path string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.fileURL(path)+"?ref="+url.QueryEscape(c.branch), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("unexpected GitLab API response while checking path (%s): %s - %s", path, resp.Status, string(body))
+	}
+}
+
+func (c *gitlabClient) PutFile(ctx context.Context, path string, data []byte, message string) (string, error) {
+	requestBody := map[string]string{
+		"branch":         c.branch,
+		"content":        base64.StdEncoding.EncodeToString(data),
+		"encoding":       "base64",
+		"commit_message": message,
+	}
+	bodyJSON, err := json.Marshal(requestBody)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.fileURL(path), bytes.NewReader(bodyJSON))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("GitLab API error: %s - %s", resp.Status, string(body))
+	}
+
+	return fmt.Sprintf("%s/%s/-/raw/%s/%s", c.baseURL, strippedProject(c.project), c.branch, path), nil
+}
+
+// strippedProject undoes the query-escaping applied to the project path so
+// it can be embedded back into a human-facing URL.
+func strippedProject(escaped string) string {
+	project, err := url.QueryUnescape(escaped)
+	if err != nil {
+		return escaped
+	}
+	return project
+}
@@ -0,0 +1,100 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/provider/provider.go 	Git-hosting provider abstraction (GitHub, GitLab, Gitea, Bitbucket)
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+// Package provider implements the "create/update file contents" REST call
+// for each Git hosting service GitUp can talk to, behind a single RepoClient
+// interface so the rest of GitUp doesn't need to know which forge it's
+// talking to.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RepoClient is implemented by every Git hosting provider GitUp supports. It
+// covers the two operations the upload flow needs: checking whether a path
+// is already taken, and writing a file to it.
+type RepoClient interface {
+	// Exists reports whether path already exists in the repository.
+	Exists(ctx context.Context, path string) (bool, error)
+	// PutFile creates path with data, committing it with message, and
+	// returns a URL the file can be viewed/fetched at. The github, gitlab,
+	// and gitea clients send a create request without an existing file's
+	// SHA, so they do not update a file already present at path.
+	PutFile(ctx context.Context, path string, data []byte, message string) (rawURL string, err error)
+}
+
+// Options configures a provider client.
+type Options struct {
+	// Owner is the repository owner or namespace.
+	Owner string
+	// Repo is the repository name.
+	Repo string
+	// Branch is the branch files are committed to.
+	Branch string
+	// Token authenticates against the provider's API.
+	Token string
+	// BaseURL overrides the provider's default API host, for self-hosted
+	// GitLab/Gitea/Forgejo/Bitbucket instances. Ignored by github.com.
+	BaseURL string
+}
+
+// httpTimeout matches the timeout the original GitHub-only client used. It
+// is sized for the small metadata/Contents-API calls every provider makes;
+// it is deliberately not reused for the Git Data API blob upload, whose
+// payload can be up to MaxLargeFileBytes (see largeUploadTimeout).
+const httpTimeout = 15 * time.Second
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: httpTimeout}
+}
+
+// minLargeUploadTimeout floors the scaled large-upload timeout so small
+// files don't inherit an unrealistically short deadline.
+const minLargeUploadTimeout = 30 * time.Second
+
+// largeUploadBytesPerSecond is a conservative throughput assumption (roughly
+// a saturated 10 Mbps uplink) used to size the blob-upload timeout to the
+// payload instead of reusing the fixed httpTimeout small API calls use.
+const largeUploadBytesPerSecond = 1 * 1024 * 1024 // 1 MB/s
+
+// largeUploadTimeout returns an HTTP timeout sized for uploading
+// payloadBytes over the Git Data API, which has no fixed small upper bound
+// the way Contents-API calls do.
+func largeUploadTimeout(payloadBytes int64) time.Duration {
+	estimated := time.Duration(payloadBytes/largeUploadBytesPerSecond) * time.Second
+	if estimated < minLargeUploadTimeout {
+		return minLargeUploadTimeout
+	}
+	return estimated
+}
+
+// New constructs the RepoClient for the named provider: "github", "gitlab",
+// "gitea" (also used for Forgejo, which speaks the same API), or "bitbucket".
+func New(name string, opts Options) (RepoClient, error) {
+	switch name {
+	case "", "github":
+		return newGitHubClient(opts), nil
+	case "gitlab":
+		return newGitLabClient(opts), nil
+	case "gitea", "forgejo":
+		if opts.BaseURL == "" {
+			return nil, fmt.Errorf("provider %q requires a base_url pointing at the instance", name)
+		}
+		return newGiteaClient(opts), nil
+	case "bitbucket":
+		return newBitbucketClient(opts), nil
+	default:
+		return nil, fmt.Errorf("unknown provider %q (available: github, gitlab, gitea, bitbucket)", name)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/secret/file.go 	Encrypted-file fallback when no OS keyring is available
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package secret
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"filippo.io/age"
+)
+
+// fileDir mirrors config.go's configDir, and fileName sits beside its config files, so the
+// fallback lives alongside the rest of GitUp's state.
+const (
+	fileDir  = ".gitup"
+	fileName = "secrets.age"
+)
+
+// secretFilePath returns the path to the encrypted secrets file, creating its
+// parent directory with restrictive permissions if necessary.
+func secretFilePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	dir := filepath.Join(homeDir, fileDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	return filepath.Join(dir, fileName), nil
+}
+
+// identity loads the age identity stored beside the secrets file (with a
+// ".key" suffix), generating and persisting a random one on first use, so
+// the fallback file can be decrypted without an interactive passphrase
+// prompt, the same trust model as storing the token in plaintext in
+// config.json did before.
+func identity() (*age.X25519Identity, error) {
+	path, err := secretFilePath()
+	if err != nil {
+		return nil, err
+	}
+	keyPath := path + ".key"
+
+	if data, err := os.ReadFile(keyPath); err == nil {
+		id, err := age.ParseX25519Identity(string(bytes.TrimSpace(data)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse stored age key: %w", err)
+		}
+		return id, nil
+	}
+
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate age key: %w", err)
+	}
+	if err := os.WriteFile(keyPath, []byte(id.String()+"\n"), 0600); err != nil {
+		return nil, fmt.Errorf("failed to persist age key: %w", err)
+	}
+	return id, nil
+}
+
+// saveToFile encrypts token for user and writes it to the secrets file,
+// replacing any existing entry for the same user.
+func saveToFile(user, token string) error {
+	id, err := identity()
+	if err != nil {
+		return err
+	}
+
+	entries, err := readEntries(id)
+	if err != nil {
+		return err
+	}
+	if entries == nil {
+		entries = map[string]string{}
+	}
+	entries[user] = token
+
+	path, err := secretFilePath()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open secrets file: %w", err)
+	}
+	defer file.Close()
+
+	writer, err := age.Encrypt(file, id.Recipient())
+	if err != nil {
+		return fmt.Errorf("failed to start encrypting secrets file: %w", err)
+	}
+	for entryUser, entryToken := range entries {
+		if _, err := fmt.Fprintf(writer, "%s=%s\n", entryUser, entryToken); err != nil {
+			return fmt.Errorf("failed to write secrets file: %w", err)
+		}
+	}
+	return writer.Close()
+}
+
+// loadFromFile decrypts the secrets file and returns the token stored for user.
+func loadFromFile(user string) (string, error) {
+	id, err := identity()
+	if err != nil {
+		return "", err
+	}
+
+	entries, err := readEntries(id)
+	if err != nil {
+		return "", err
+	}
+	token, ok := entries[user]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return token, nil
+}
+
+// readEntries decrypts the secrets file into a user->token map. A missing
+// file is treated as an empty map rather than an error.
+func readEntries(id *age.X25519Identity) (map[string]string, error) {
+	path, err := secretFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to open secrets file: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := age.Decrypt(file, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets file: %w", err)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets file: %w", err)
+	}
+
+	entries := map[string]string{}
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		parts := bytes.SplitN(line, []byte("="), 2)
+		if len(parts) != 2 {
+			continue
+		}
+		entries[string(parts[0])] = string(parts[1])
+	}
+	return entries, nil
+}
@@ -0,0 +1,71 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/secret/secret.go 	Cross-platform token storage
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+// Package secret stores and retrieves GitUp's access token using the host
+// OS's native credential store (macOS Keychain, GNOME libsecret/KWallet,
+// Windows Credential Manager) via go-keyring, falling back to an
+// age-encrypted file on disk when no keyring backend is available.
+package secret
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// serviceName identifies GitUp's entries in the OS credential store. It is
+// unchanged from the macOS-only implementation so existing installs keep
+// working after upgrading.
+const serviceName = "GitUp"
+
+// ErrNotFound is returned by Load when no token is stored for user in either
+// the OS keyring or the encrypted file fallback.
+var ErrNotFound = errors.New("no token found in OS keyring or encrypted file")
+
+// BackendKeyring and BackendFile identify which backend a Save call used, so
+// callers can report it to the user the way the old macOS-only code did.
+const (
+	BackendKeyring = "OS keyring"
+	BackendFile    = "encrypted file"
+)
+
+// Save stores token under user, preferring the OS keyring and falling back
+// to an age-encrypted file on disk if no keyring backend is available. It
+// returns which backend ended up holding the token.
+func Save(user, token string) (backend string, err error) {
+	if err := keyring.Set(serviceName, user, token); err == nil {
+		return BackendKeyring, nil
+	}
+
+	if err := saveToFile(user, token); err != nil {
+		return "", err
+	}
+	return BackendFile, nil
+}
+
+// Load retrieves the token previously stored for user, checking the OS
+// keyring first and the encrypted file fallback second.
+func Load(user string) (string, error) {
+	if token, err := keyring.Get(serviceName, user); err == nil {
+		return token, nil
+	}
+
+	token, err := loadFromFile(user)
+	if err != nil {
+		return "", ErrNotFound
+	}
+	return token, nil
+}
+
+// Rotate replaces the token stored for user with newToken, returning the
+// backend it was written to (see Save).
+func Rotate(user, newToken string) (backend string, err error) {
+	return Save(user, newToken)
+}
@@ -0,0 +1,71 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/storage/gcs.go 	Google Cloud Storage driver
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+)
+
+func init() {
+	Register("gcs", newGCSDriver)
+}
+
+// gcsDriver uploads files to a Google Cloud Storage bucket, authenticating
+// via Application Default Credentials.
+type gcsDriver struct {
+	client *storage.Client
+	bucket string
+}
+
+func newGCSDriver(cfg Config) (Storage, error) {
+	if cfg.Source == "" {
+		return nil, fmt.Errorf("gcs driver requires a bucket name (set \"source\" in the config)")
+	}
+
+	client, err := storage.NewClient(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+
+	return &gcsDriver{client: client, bucket: cfg.Source}, nil
+}
+
+func (d *gcsDriver) Exists(ctx context.Context, path string) (bool, error) {
+	_, err := d.client.Bucket(d.bucket).Object(path).Attrs(ctx)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (d *gcsDriver) Put(ctx context.Context, path string, data []byte, meta Metadata) (string, error) {
+	writer := d.client.Bucket(d.bucket).Object(path).NewWriter(ctx)
+	writer.ContentType = meta.ContentType
+
+	if _, err := io.Copy(writer, bytes.NewReader(data)); err != nil {
+		_ = writer.Close()
+		return "", fmt.Errorf("gcs upload failed: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("gcs upload failed: %w", err)
+	}
+
+	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", d.bucket, path), nil
+}
@@ -0,0 +1,108 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/storage/githost.go 	Git-hosting driver (GitHub, GitLab, Gitea, Bitbucket)
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thaikolja/gitup/internal/provider"
+)
+
+func init() {
+	Register("github", newGitHostDriver)
+}
+
+// contentsAPICap is the practical limit of the Contents API's base64
+// request body; files larger than this need the Git Data API instead.
+const contentsAPICap = 25 * 1024 * 1024 // 25 MB
+
+// githostDriver uploads files by committing them through a Git hosting
+// provider's REST API. It is GitUp's original upload path, generalized from
+// GitHub-only to also support GitLab, Gitea/Forgejo, and Bitbucket, selected
+// via Config.Provider. It remains registered as the "github" driver, which
+// stays the default for backwards compatibility.
+type githostDriver struct {
+	client   provider.RepoClient
+	large    bool
+	progress bool
+}
+
+func newGitHostDriver(cfg Config) (Storage, error) {
+	parts := strings.Split(cfg.Repository, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid repository format. Use: owner/repo")
+	}
+
+	client, err := provider.New(cfg.Provider, provider.Options{
+		Owner:   parts[0],
+		Repo:    parts[1],
+		Branch:  cfg.Branch,
+		Token:   cfg.Token,
+		BaseURL: cfg.BaseURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &githostDriver{client: client, large: cfg.Large, progress: cfg.Progress}, nil
+}
+
+func (d *githostDriver) Exists(ctx context.Context, path string) (bool, error) {
+	return d.client.Exists(ctx, path)
+}
+
+func (d *githostDriver) Put(ctx context.Context, path string, data []byte, meta Metadata) (string, error) {
+	commitMessage := meta.CommitMessage
+	if commitMessage == "" {
+		commitMessage = fmt.Sprintf("Upload %s via GitUp", filepath.Base(path))
+	}
+
+	if d.large || len(data) > contentsAPICap {
+		uploader, ok := d.client.(provider.LargeFileUploader)
+		if !ok {
+			return "", fmt.Errorf("file is %d bytes, which exceeds the %d byte Contents API limit, and the %T provider has no large-file upload path", len(data), contentsAPICap, d.client)
+		}
+		return uploader.PutLargeFile(ctx, path, data, commitMessage, d.onProgress)
+	}
+
+	return d.client.PutFile(ctx, path, data, commitMessage)
+}
+
+// PutBatch commits files as a single Git commit when the underlying
+// provider supports it (github), and returns ErrBatchUnsupported otherwise
+// so callers can fall back to individual Put calls.
+func (d *githostDriver) PutBatch(ctx context.Context, files []BatchFile, meta Metadata) (map[string]string, error) {
+	batcher, ok := d.client.(provider.BatchRepoClient)
+	if !ok {
+		return nil, ErrBatchUnsupported
+	}
+
+	providerFiles := make([]provider.BatchFile, len(files))
+	for i, file := range files {
+		providerFiles[i] = provider.BatchFile{Path: file.Path, Data: file.Data}
+	}
+
+	return batcher.PutBatch(ctx, providerFiles, meta.CommitMessage)
+}
+
+func (d *githostDriver) onProgress(written, total int64) {
+	if !d.progress {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\ruploaded %d/%d bytes", written, total)
+	if written >= total {
+		fmt.Fprintln(os.Stderr)
+	}
+}
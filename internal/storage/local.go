@@ -0,0 +1,62 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/storage/local.go 	Local-filesystem driver
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func init() {
+	Register("local", newLocalDriver)
+}
+
+// localDriver copies files into a directory on disk instead of uploading them
+// anywhere. It is mainly useful for testing folder routing and templates
+// without spending API calls.
+type localDriver struct {
+	root string
+}
+
+func newLocalDriver(cfg Config) (Storage, error) {
+	root := cfg.Source
+	if root == "" {
+		return nil, fmt.Errorf("local driver requires a destination directory (set \"source\" in the config)")
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage root: %w", err)
+	}
+	return &localDriver{root: root}, nil
+}
+
+func (d *localDriver) Exists(_ context.Context, path string) (bool, error) {
+	_, err := os.Stat(filepath.Join(d.root, path))
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (d *localDriver) Put(_ context.Context, path string, data []byte, _ Metadata) (string, error) {
+	fullPath := filepath.Join(d.root, path)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create destination folder: %w", err)
+	}
+	if err := os.WriteFile(fullPath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	return "file://" + fullPath, nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/storage/s3.go 	Amazon S3 (and S3-compatible) driver
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+func init() {
+	Register("s3", newS3Driver)
+}
+
+// s3Driver uploads files to an S3 bucket, using Endpoint to support
+// S3-compatible providers (MinIO, R2, Spaces) rather than AWS directly.
+type s3Driver struct {
+	client *s3.Client
+	bucket string
+}
+
+func newS3Driver(cfg Config) (Storage, error) {
+	if cfg.Source == "" {
+		return nil, fmt.Errorf("s3 driver requires a bucket name (set \"source\" in the config)")
+	}
+
+	optFns := []func(*config.LoadOptions) error{}
+	if cfg.Region != "" {
+		optFns = append(optFns, config.WithRegion(cfg.Region))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(context.Background(), optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	return &s3Driver{client: client, bucket: cfg.Source}, nil
+}
+
+func (d *s3Driver) Exists(ctx context.Context, path string) (bool, error) {
+	_, err := d.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(path),
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	// HeadObject doesn't return a typed NotFound the way some other S3 calls
+	// do, but the SDK still wraps it in a smithy response error carrying the
+	// real HTTP status, so check that instead of treating every failure
+	// (auth, network, throttling) as "does not exist" the way a bare error
+	// check would.
+	var responseErr *smithyhttp.ResponseError
+	if errors.As(err, &responseErr) && responseErr.HTTPStatusCode() == 404 {
+		return false, nil
+	}
+	return false, err
+}
+
+func (d *s3Driver) Put(ctx context.Context, path string, data []byte, meta Metadata) (string, error) {
+	_, err := d.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(d.bucket),
+		Key:         aws.String(path),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(meta.ContentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("s3 upload failed: %w", err)
+	}
+	return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", d.bucket, path), nil
+}
@@ -0,0 +1,124 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: internal/storage/storage.go 	Pluggable upload-destination interface and driver registry
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+// Package storage defines the driver-based abstraction GitUp uses to put a
+// file somewhere and get a public URL back. Concrete destinations (GitHub,
+// S3, GCS, a local directory, or a generic Git remote) each implement the
+// Storage interface and register themselves under a short driver name.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrBatchUnsupported is returned by BatchStorage.PutBatch when the
+// underlying driver or provider has no atomic multi-file commit path.
+// Callers should fall back to individual Put calls.
+var ErrBatchUnsupported = errors.New("storage driver does not support atomic batch uploads")
+
+// BatchFile pairs a destination path with its file contents for a
+// multi-file Put.
+type BatchFile struct {
+	Path string
+	Data []byte
+}
+
+// BatchStorage is implemented by drivers that can write multiple files in a
+// single atomic operation (e.g. one Git commit) instead of N separate Puts.
+// It returns a map from each file's Path to its public URL.
+type BatchStorage interface {
+	PutBatch(ctx context.Context, files []BatchFile, meta Metadata) (map[string]string, error)
+}
+
+// Metadata carries the information a driver may need to decide how to name,
+// tag, or describe the object it stores, beyond the raw bytes themselves.
+type Metadata struct {
+	// Filename is the sanitized, already-unique name the object should be
+	// stored under (drivers are free to nest it under their own folders).
+	Filename string
+	// ContentType is the best-guess MIME type of the data, if known.
+	ContentType string
+	// CommitMessage is used by drivers that create a VCS commit (github, git).
+	CommitMessage string
+}
+
+// Storage is implemented by every upload destination GitUp supports. Put
+// stores data at path and returns a URL the caller can present to the user;
+// Exists reports whether something is already stored at that path so callers
+// can pick a unique name before uploading.
+type Storage interface {
+	Put(ctx context.Context, path string, data []byte, meta Metadata) (publicURL string, err error)
+	Exists(ctx context.Context, path string) (bool, error)
+}
+
+// Config is the subset of GitUp's configuration a driver needs to construct
+// itself. Not every field is meaningful to every driver.
+type Config struct {
+	// Repository is the target repository in "owner/repo" form (github driver).
+	Repository string
+	// Branch is the Git branch uploads are committed to (github, git drivers).
+	Branch string
+	// Token authenticates against the remote API (github driver).
+	Token string
+	// Provider selects which Git hosting service the github driver talks to:
+	// github (default), gitlab, gitea/forgejo, or bitbucket.
+	Provider string
+	// BaseURL overrides the provider's default API host, for self-hosted
+	// GitLab/Gitea/Forgejo/Bitbucket instances.
+	BaseURL string
+	// Source is the bucket name (s3, gcs drivers) or local directory (local
+	// driver) the driver writes to, mirroring soju's fileupload.New(driver, source).
+	Source string
+	// Region is the cloud region to use, when the driver requires one (s3).
+	Region string
+	// Endpoint overrides the default API endpoint, for S3-compatible stores.
+	Endpoint string
+	// SSHRemote is the "user@host:path" or "ssh://" remote to push to (git driver).
+	SSHRemote string
+	// Large forces the large-file upload path even for files under the
+	// driver's normal size threshold (github driver).
+	Large bool
+	// Progress prints bytes-uploaded counters during large-file uploads
+	// (github driver).
+	Progress bool
+	// Verbose carries the -v flag; none of the built-in drivers reads it.
+	Verbose bool
+}
+
+// Factory constructs a Storage driver from a Config.
+type Factory func(cfg Config) (Storage, error)
+
+var drivers = map[string]Factory{}
+
+// Register makes a driver available under name. It is expected to be called
+// from a driver's init function, following the database/sql driver pattern.
+func Register(name string, factory Factory) {
+	drivers[name] = factory
+}
+
+// New constructs the Storage driver registered under name. It returns an
+// error if no driver has been registered with that name.
+func New(name string, cfg Config) (Storage, error) {
+	factory, ok := drivers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown storage driver %q (available: %s)", name, availableNames())
+	}
+	return factory(cfg)
+}
+
+func availableNames() string {
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	return fmt.Sprint(names)
+}
@@ -11,65 +11,107 @@
 package main
 
 import (
-	"bytes"
-	"encoding/base64"
-	"encoding/json"
+	"context"
 	"flag"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
-	"time"
+
+	"github.com/thaikolja/gitup/internal/storage"
 )
 
 const (
-	// configDir is the directory under the user's home where the configuration is stored.
-	configDir = ".gitup"
-	// configFile is the filename for the JSON configuration file.
-	configFile = "config.json"
+	// defaultDriver is used when the config file and --driver flag don't pick one.
+	defaultDriver = "github"
 
-	maxUploadSizeBytes = 25 * 1024 * 1024 // 25 MB practical limit for GitHub API
+	maxUploadSizeBytes = 100 * 1024 * 1024 // 100 MB, GitHub's blob limit via the Git Data API
 )
 
-// Config holds the user's GitUp configuration.
-type Config struct {
-	// Token is the GitHub Personal Access Token used to authenticate API requests.
-	Token string `json:"token"`
-	// Repository is the target repository in the format owner/repo.
-	Repository string `json:"repository"`
-}
-
 func main() {
 	var (
-		configCmd = flag.Bool("config", false, "Configure GitUp")
-		verbose   = flag.Bool("v", false, "Enable verbose logging")
-		branch    = flag.String("branch", "main", "Git branch for uploaded files")
+		configCmd   = flag.Bool("config", false, "Configure GitUp")
+		rotateToken = flag.Bool("rotate-token", false, "With -config, replace the stored token without re-entering other settings")
+		verbose     = flag.Bool("v", false, "Enable verbose logging")
+		branch      = flag.String("branch", "main", "Git branch for uploaded files")
+		driver      = flag.String("driver", "", "Storage driver to use (github, s3, gcs, local, git); overrides the config file")
+		large       = flag.Bool("large", false, "Force the Git Data API upload path, even for files under the Contents API limit")
+		progress    = flag.Bool("progress", false, "Print bytes-uploaded progress during large-file uploads")
+		concurrency = flag.Int("concurrency", runtime.NumCPU(), "Worker pool size for batch/directory uploads")
+		message     = flag.String("message", "", "Commit message template for batch uploads ({count}, {branch} placeholders)")
+		dryRun      = flag.Bool("dry-run", false, "Preview batch upload routing without uploading anything")
+		name        = flag.String("name", "", "Filename to use for stdin or --clipboard input (required for stdin)")
+		clip        = flag.Bool("clipboard", false, "Read an image or text from the system clipboard instead of a file path")
+		open        = flag.Bool("open", false, "Copy the resulting URL back to the clipboard after a successful upload")
 	)
 	flag.Usage = func() {
-		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [options] <file-path>\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [options] <file-path> [more-paths...]\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "       %s [options] -           (read a single file from stdin)\n", os.Args[0])
 		fmt.Fprintln(flag.CommandLine.Output(), "Options:")
 		flag.PrintDefaults()
 	}
 	flag.Parse()
 
 	if *configCmd {
-		configureGitUp()
+		if *rotateToken {
+			rotateStoredToken()
+		} else {
+			configureGitUp()
+		}
 		return
 	}
 
 	args := flag.Args()
-	if len(args) == 0 {
-		flag.Usage()
-		os.Exit(1)
+
+	var (
+		streaming  bool
+		streamData []byte
+		streamName string
+	)
+	switch {
+	case *clip:
+		if len(args) != 0 {
+			fmt.Fprintln(os.Stderr, "Error: --clipboard cannot be combined with a file path")
+			os.Exit(1)
+		}
+		data, filename, err := readClipboard(*name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read clipboard: %v\n", err)
+			os.Exit(1)
+		}
+		streaming, streamData, streamName = true, data, filename
+
+	case len(args) == 1 && args[0] == "-":
+		data, err := readStdin(*name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read stdin: %v\n", err)
+			os.Exit(1)
+		}
+		streaming, streamData, streamName = true, data, *name
+
+	case len(args) == 0:
+		if !isStdinPiped() {
+			flag.Usage()
+			os.Exit(1)
+		}
+		data, err := readStdin(*name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read stdin: %v\n", err)
+			os.Exit(1)
+		}
+		streaming, streamData, streamName = true, data, *name
 	}
 
-	filePath := args[0]
-	if err := validateInputFile(filePath); err != nil {
-		fmt.Fprintf(os.Stderr, "Invalid file: %v\n", err)
-		os.Exit(1)
+	var batchMode bool
+	if !streaming {
+		batchMode = len(args) > 1 || isDirectory(args[0])
+		if !batchMode {
+			if err := validateInputFile(args[0]); err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid file: %v\n", err)
+				os.Exit(1)
+			}
+		}
 	}
 
 	config, err := loadConfig()
@@ -79,152 +121,115 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := validateRepository(config.Repository); err != nil {
-		fmt.Fprintf(os.Stderr, "Invalid repository: %v\n", err)
-		os.Exit(1)
+	driverName := config.Driver
+	if *driver != "" {
+		driverName = *driver
 	}
-
-	uploader := &Uploader{
-		Client:     &http.Client{Timeout: 15 * time.Second},
-		Branch:     *branch,
-		Verbose:    *verbose,
-		Repository: config.Repository,
-		Token:      config.Token,
+	if driverName == "" {
+		driverName = defaultDriver
 	}
 
-	if err := uploader.Upload(filePath); err != nil {
-		fmt.Fprintf(os.Stderr, "Upload failed: %v\n", err)
-		os.Exit(1)
-	}
-}
-
-// configureGitUp runs an interactive configuration flow.
-// It prompts the user for a GitHub token and repository, and attempts to save the token
-// to the macOS Keychain, and writes the remainder of the config to disk.
-func configureGitUp() {
-	fmt.Println("=== GitUp Configuration ===")
-
-	var config Config
-
-	// Get GitHub token
-	fmt.Print("Enter your GitHub Personal Access Token: ")
-	if _, err := fmt.Scanln(&config.Token); err != nil {
-		fmt.Printf("Error reading token: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Get repository
-	fmt.Print("Enter repository (owner/repo): ")
-	if _, err := fmt.Scanln(&config.Repository); err != nil {
-		fmt.Printf("Error reading repository: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Save to keychain
-	err := saveToKeychain(config.Token)
-	if err != nil {
-		fmt.Printf("Warning: Could not save to keychain: %v\n", err)
-		fmt.Println("Token will be saved in config file instead")
-	} else {
-		fmt.Println("✓ Token saved to macOS Keychain")
-		config.Token = "" // Don't store in file if in keychain
+	switch driverName {
+	case "github":
+		if err := validateRepository(config.Repository); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid repository: %v\n", err)
+			os.Exit(1)
+		}
+	case "git":
+		if config.SSHRemote == "" {
+			fmt.Fprintln(os.Stderr, "Invalid config: the git driver requires \"ssh_remote\" to be set")
+			os.Exit(1)
+		}
 	}
 
-	// Save config
-	err = saveConfig(config)
+	backend, err := storage.New(driverName, storage.Config{
+		Repository: config.Repository,
+		Branch:     *branch,
+		Token:      config.Token,
+		Provider:   config.Provider,
+		BaseURL:    config.BaseURL,
+		Source:     config.Source,
+		Region:     config.Region,
+		Endpoint:   config.Endpoint,
+		SSHRemote:  config.SSHRemote,
+		Large:      *large,
+		Progress:   *progress,
+		Verbose:    *verbose,
+	})
 	if err != nil {
-		fmt.Printf("Error saving config: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Failed to initialize %s driver: %v\n", driverName, err)
 		os.Exit(1)
 	}
+	// Some drivers (git) hold a resource beyond the Storage interface itself
+	// (a local clone under the OS temp dir); clean it up once this invocation
+	// is done, the same optional-capability pattern used for LargeFileUploader
+	// and BatchStorage.
+	if closer, ok := backend.(interface{ Close() error }); ok {
+		defer closer.Close()
+	}
+
+	if batchMode {
+		batchUploader := &BatchUploader{
+			Storage:         backend,
+			Config:          config,
+			Concurrency:     *concurrency,
+			MessageTemplate: *message,
+			Branch:          *branch,
+			DryRun:          *dryRun,
+		}
 
-	fmt.Println("✓ Configuration saved!")
-}
-
-// saveToKeychain stores the provided token in the macOS Keychain using the
-// `security` CLI. The entry is tagged with the current username and the service
-// name "GitUp".
-func saveToKeychain(token string) error {
-	cmd := exec.Command("security", "add-generic-password",
-		"-a", os.Getenv("USER"),
-		"-s", "GitUp",
-		"-w", token,
-		"-U") // -U updates if exists
-	return cmd.Run()
-}
-
-// loadFromKeychain retrieves the token previously saved under the "GitUp."
-// service in the macOS Keychain using the `security` CLI.
-func loadFromKeychain() (string, error) {
-	cmd := exec.Command("security", "find-generic-password",
-		"-a", os.Getenv("USER"),
-		"-s", "GitUp",
-		"-w")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSpace(string(output)), nil
-}
-
-// saveConfig writes the provided Config to the user's config directory as JSON.
-// The config directory is created with restrictive permissions.
-func saveConfig(config Config) error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to determine home directory: %w", err)
-	}
-	configPath := filepath.Join(homeDir, configDir)
-
-	// Create config directory
-	if err := os.MkdirAll(configPath, 0700); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
-	}
+		results, err := batchUploader.Upload(context.Background(), args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Batch upload failed: %v\n", err)
+			os.Exit(1)
+		}
 
-	// Write config file
-	data, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		exitCode := 0
+		for _, result := range results {
+			if result.Err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", result.SourcePath, result.Err)
+				exitCode = 1
+				continue
+			}
+			if *dryRun {
+				fmt.Println(result.UploadPath)
+				continue
+			}
+			fmt.Println(formatOutput(filepath.Base(result.UploadPath), result.URL, config))
+		}
+		os.Exit(exitCode)
 	}
-	configFilePath := filepath.Join(configPath, configFile)
-	return os.WriteFile(configFilePath, data, 0600)
-}
 
-// loadConfig reads the JSON configuration file from disk, unmarshals it into a
-// Config, and attempts to load the token from the keychain if it is not present
-// in the file.
-func loadConfig() (Config, error) {
-	var config Config
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return config, fmt.Errorf("failed to determine home directory: %w", err)
+	uploader := &Uploader{
+		Storage: backend,
+		Config:  config,
+		Verbose: *verbose,
 	}
-	configFilePath := filepath.Join(homeDir, configDir, configFile)
 
-	// Read config file
-	data, err := os.ReadFile(configFilePath)
-	if err != nil {
-		return config, err
+	var publicURL string
+	if streaming {
+		publicURL, err = uploader.UploadBytes(streamName, streamData)
+	} else {
+		publicURL, err = uploader.Upload(args[0])
 	}
-
-	err = json.Unmarshal(data, &config)
 	if err != nil {
-		return config, err
+		fmt.Fprintf(os.Stderr, "Upload failed: %v\n", err)
+		os.Exit(1)
 	}
 
-	// Try to load token from keychain if not in config
-	if config.Token == "" {
-		token, err := loadFromKeychain()
-		if err == nil {
-			config.Token = token
+	if *open {
+		if err := copyURLToClipboard(publicURL); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not copy URL to clipboard: %v\n", err)
 		}
 	}
+}
 
-	if err := validateRepository(config.Repository); err != nil {
-		return config, err
-	}
-
-	return config, nil
+// isDirectory reports whether path refers to an existing directory. A
+// nonexistent path is treated as "not a directory" so the later single-file
+// validation can produce its own specific error message.
+func isDirectory(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
 }
 
 func validateRepository(repo string) error {
@@ -317,91 +322,17 @@ func sanitizeFilename(name string) string {
 	return sanitized + ext
 }
 
-// getUploadFolder returns the appropriate folder under the repository based on
-// the file's extension. Unknown extensions map to "files".
-func getUploadFolder(filename string) string {
-	ext := strings.ToLower(filepath.Ext(filename))
-
-	// Map extensions to folders
-	folderMap := map[string]string{
-		// Images
-		".png":  "img",
-		".jpg":  "img",
-		".jpeg": "img",
-		".gif":  "img",
-		".svg":  "img",
-		".webp": "img",
-		".ico":  "img",
-
-		// Data files
-		".json": "data",
-		".xml":  "data",
-		".csv":  "data",
-		".yaml": "data",
-		".yml":  "data",
-		".toml": "data",
-
-		// Documents
-		".pdf":  "docs",
-		".md":   "docs",
-		".txt":  "docs",
-		".doc":  "docs",
-		".docx": "docs",
-
-		// Videos
-		".mp4":  "video",
-		".mov":  "video",
-		".avi":  "video",
-		".webm": "video",
-
-		// Audio
-		".mp3":  "audio",
-		".wav":  "audio",
-		".ogg":  "audio",
-		".flac": "audio",
-
-		// Archives
-		".zip": "archives",
-		".tar": "archives",
-		".gz":  "archives",
-		".rar": "archives",
-	}
-
-	if folder, exists := folderMap[ext]; exists {
-		return folder
-	}
-
-	// Default folder for unknown extensions
-	return "files"
-}
-
-// formatOutput returns a markdown-formatted string appropriate for the file type.
-// Images are rendered using markdown image syntax, other files use a link.
-func formatOutput(filename, url string) string {
-	ext := strings.ToLower(filepath.Ext(filename))
-
-	// Image extensions use markdown image syntax
-	imageExts := []string{".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp"}
-	for _, imgExt := range imageExts {
-		if ext == imgExt {
-			return fmt.Sprintf("![%s](%s)", filename, url)
-		}
-	}
-
-	// Everything else uses markdown link syntax
-	return fmt.Sprintf("[%s](%s)", filename, url)
-}
-
-// ensureUniqueFilename checks if the given path already exists in the repository
-// and appends -1, -2, etc. before the extension until a free name is found.
-func ensureUniqueFilename(owner, repo, folder, filename, token string) (string, error) {
+// ensureUniqueFilename checks if the given path already exists at the storage
+// destination and appends -1, -2, etc. before the extension until a free name
+// is found.
+func ensureUniqueFilename(ctx context.Context, backend storage.Storage, folder, filename string) (string, error) {
 	base := strings.TrimSuffix(filename, filepath.Ext(filename))
 	ext := filepath.Ext(filename)
 
 	candidate := filename
 	counter := 1
 	for {
-		exists, err := pathExistsOnGitHub(owner, repo, folder, candidate, token)
+		exists, err := backend.Exists(ctx, filepath.Join(folder, candidate))
 		if err != nil {
 			return "", err
 		}
@@ -413,44 +344,6 @@ func ensureUniqueFilename(owner, repo, folder, filename, token string) (string,
 	}
 }
 
-// pathExistsOnGitHub performs a HEAD request against the GitHub contents API to
-// determine whether a file already exists at the given folder and filename.
-func pathExistsOnGitHub(owner, repo, folder, filename, token string) (bool, error) {
-	path := filepath.Join(folder, filename)
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", owner, repo, path)
-
-	req, err := http.NewRequest("HEAD", url, nil)
-	if err != nil {
-		return false, err
-	}
-	if token != "" {
-		req.Header.Set("Authorization", "token "+token)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return false, err
-	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to close response body: %v\n", err)
-		}
-	}(resp.Body)
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		return true, nil
-	case http.StatusNotFound:
-		return false, nil
-	case http.StatusUnauthorized, http.StatusForbidden:
-		return false, fmt.Errorf("GitHub API auth error while checking path (%s): %s", path, resp.Status)
-	default:
-		body, _ := io.ReadAll(resp.Body)
-		return false, fmt.Errorf("unexpected GitHub API response while checking path (%s): %s - %s", path, resp.Status, string(body))
-	}
-}
-
 // validateInputFile checks if the given file path refers to a valid, accessible file.
 func validateInputFile(filePath string) error {
 	info, err := os.Stat(filePath)
@@ -473,87 +366,56 @@ func validateInputFile(filePath string) error {
 	return nil
 }
 
-// Uploader is a struct that handles file uploading with configurable options.
+// Uploader drives a single file through sanitization, folder routing, and a
+// pluggable storage.Storage backend.
 type Uploader struct {
-	Client     *http.Client // HTTP client for making requests
-	Branch     string       // Git branch for uploaded files
-	Verbose    bool         // Enable verbose logging
-	Repository string       // Target repository in the format owner/repo
-	Token      string       // GitHub Personal Access Token
+	Storage storage.Storage // Destination the file is uploaded to
+	Config  Config          // Folder routing, commit message, and output template settings
+	Verbose bool            // Enable verbose logging
 }
 
-// Upload uploads the given file to the configured GitHub repository.
-func (u *Uploader) Upload(filePath string) error {
-	// Read file
+// Upload reads filePath from disk and uploads it to the configured storage
+// destination, returning the file's public URL.
+func (u *Uploader) Upload(filePath string) (string, error) {
 	fileData, err := os.ReadFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %v", err)
+		return "", fmt.Errorf("failed to read file: %v", err)
 	}
+	return u.upload(filepath.Base(filePath), fileData)
+}
 
-	// Get filename
-	filename := filepath.Base(filePath)
+// UploadBytes uploads data already held in memory (stdin, clipboard) under
+// filename, returning the file's public URL.
+func (u *Uploader) UploadBytes(filename string, data []byte) (string, error) {
+	return u.upload(filename, data)
+}
 
+func (u *Uploader) upload(filename string, fileData []byte) (string, error) {
 	// Sanitize filename
 	sanitizedFilename := sanitizeFilename(filename)
 
 	// Determine upload folder based on file extension
-	folder := getUploadFolder(sanitizedFilename)
+	folder := getUploadFolder(sanitizedFilename, u.Config)
 
-	// Construct GitHub API URL
-	parts := strings.Split(u.Repository, "/")
-	if len(parts) != 2 {
-		return fmt.Errorf("invalid repository format. Use: owner/repo")
-	}
-	owner, repo := parts[0], parts[1]
+	ctx := context.Background()
 
-	uniqueFilename, err := ensureUniqueFilename(owner, repo, folder, sanitizedFilename, u.Token)
+	uniqueFilename, err := ensureUniqueFilename(ctx, u.Storage, folder, sanitizedFilename)
 	if err != nil {
-		return fmt.Errorf("failed to determine unique filename: %w", err)
+		return "", fmt.Errorf("failed to determine unique filename: %w", err)
 	}
 
 	uploadPath := filepath.Join(folder, uniqueFilename)
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s",
-		owner, repo, uploadPath)
 
-	// Prepare request body
-	requestBody := map[string]string{
-		"message": fmt.Sprintf("Upload %s via GitUp", filename),
-		"content": base64.StdEncoding.EncodeToString(fileData),
-	}
-
-	bodyJSON, _ := json.Marshal(requestBody)
-
-	// Create HTTP request
-	req, err := http.NewRequest("PUT", apiURL, bytes.NewReader(bodyJSON))
-	if err != nil {
-		return err
-	}
-
-	req.Header.Set("Authorization", "token "+u.Token)
-	req.Header.Set("Content-Type", "application/json")
-
-	// Send request
-	resp, err := u.Client.Do(req)
+	publicURL, err := u.Storage.Put(ctx, uploadPath, fileData, storage.Metadata{
+		Filename:      uniqueFilename,
+		CommitMessage: renderCommitMessage(u.Config, filename),
+	})
 	if err != nil {
-		return err
-	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-
-		}
-	}(resp.Body)
-
-	if resp.StatusCode != 201 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("GitHub API error: %s - %s", resp.Status, string(body))
+		return "", err
 	}
 
 	// Print success message with URL
-	rawURL := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", owner, repo, u.Branch, uploadPath)
-	output := formatOutput(filename, rawURL)
-
-	fmt.Println(output)
+	fmt.Println(formatOutput(filename, publicURL, u.Config))
 
-	return nil
+	return publicURL, nil
 }
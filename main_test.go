@@ -0,0 +1,79 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: main_test.go 	Tests for filename sanitizing and uniqueness
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/thaikolja/gitup/internal/storage"
+)
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"My Screenshot.PNG", "my-screenshot.png"},
+		{"résumé.pdf", "resume.pdf"},
+		{"!!!.txt", "file.txt"},
+		{"already-sane.jpg", "already-sane.jpg"},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizeFilename(tt.name); got != tt.want {
+			t.Errorf("sanitizeFilename(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// fakeStorage is a Storage backend whose Exists answers are fixed in advance,
+// so ensureUniqueFilename's collision handling can be tested without a real
+// network-backed driver.
+type fakeStorage struct {
+	taken map[string]bool
+}
+
+func (f *fakeStorage) Exists(_ context.Context, path string) (bool, error) {
+	return f.taken[path], nil
+}
+
+func (f *fakeStorage) Put(_ context.Context, path string, _ []byte, _ storage.Metadata) (string, error) {
+	return path, nil
+}
+
+func TestEnsureUniqueFilenameNoCollision(t *testing.T) {
+	backend := &fakeStorage{taken: map[string]bool{}}
+
+	got, err := ensureUniqueFilename(context.Background(), backend, "img", "photo.png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "photo.png" {
+		t.Errorf("got %q, want %q", got, "photo.png")
+	}
+}
+
+func TestEnsureUniqueFilenameAppendsSuffix(t *testing.T) {
+	backend := &fakeStorage{taken: map[string]bool{
+		filepath.Join("img", "photo.png"):   true,
+		filepath.Join("img", "photo-1.png"): true,
+	}}
+
+	got, err := ensureUniqueFilename(context.Background(), backend, "img", "photo.png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "photo-2.png" {
+		t.Errorf("got %q, want %q", got, "photo-2.png")
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * Project: GitUp 	Command-line tool to upload files directly to GitHub
+ * File: stream.go 	Stdin and clipboard input sources
+ * Version: 		v1.0.0
+ * Author: 			Kolja Nolte
+ * Author URL: 		https://www.kolja-nolte.com
+ * License: 		MIT
+ * Repository: 		https://github.com/thaikolja/gitup
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.design/x/clipboard"
+)
+
+// isStdinPiped reports whether stdin is connected to something other than an
+// interactive terminal (a pipe, redirect, or here-doc), so `gitup` with no
+// path can be routed to the stdin reader automatically.
+func isStdinPiped() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice == 0
+}
+
+// readStdin buffers all of stdin into memory so its Content-Length can be
+// computed up front, same as a file read via os.ReadFile. Stdin carries no
+// filename, so name (--name) is required.
+func readStdin(name string) ([]byte, error) {
+	if name == "" {
+		return nil, fmt.Errorf("--name is required when reading from stdin")
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdin: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("stdin is empty")
+	}
+
+	return data, nil
+}
+
+// readClipboard reads an image, falling back to text, from the system
+// clipboard. name overrides the destination filename; when empty, a
+// timestamped name is derived from whichever content was found.
+func readClipboard(name string) (data []byte, filename string, err error) {
+	if err := clipboard.Init(); err != nil {
+		return nil, "", fmt.Errorf("failed to access clipboard: %w", err)
+	}
+
+	if data = clipboard.Read(clipboard.FmtImage); len(data) > 0 {
+		if name == "" {
+			name = fmt.Sprintf("clipboard-%d.png", time.Now().Unix())
+		}
+		return data, name, nil
+	}
+
+	if data = clipboard.Read(clipboard.FmtText); len(data) > 0 {
+		if name == "" {
+			name = fmt.Sprintf("clipboard-%d.txt", time.Now().Unix())
+		}
+		return data, name, nil
+	}
+
+	return nil, "", fmt.Errorf("clipboard is empty or contains unsupported content")
+}
+
+// copyURLToClipboard writes url to the system clipboard, for --open.
+func copyURLToClipboard(url string) error {
+	if err := clipboard.Init(); err != nil {
+		return fmt.Errorf("failed to access clipboard: %w", err)
+	}
+	clipboard.Write(clipboard.FmtText, []byte(url))
+	return nil
+}